@@ -0,0 +1,76 @@
+package gsync
+
+import (
+	"fmt"
+	"sync"
+)
+
+// cacheEntry holds the state for a single key in a CacheMap: either a
+// computation in flight, or a completed result. done is closed once val/err
+// are safe to read.
+type cacheEntry[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// CacheMap is a generic, singleflight-style wrapper around sync.Map. Unlike
+// Map's Compute helpers, CacheMap.LoadOrCompute guarantees that concurrent
+// callers racing to fill the same missing key observe f invoked exactly
+// once, with every caller blocking on and receiving that single result.
+type CacheMap[K comparable, V any] struct {
+	data sync.Map
+}
+
+// LoadOrCompute returns the existing value for key if present. Otherwise it
+// calls f exactly once, even if multiple goroutines call LoadOrCompute for
+// the same missing key concurrently: the first caller runs f while the rest
+// block on its result. The loaded result reports whether the value came
+// from an existing entry rather than this call's own invocation of f. If f
+// returns an error, the entry is removed so a later call retries. If f
+// panics, the entry is likewise removed and every waiter unblocks with an
+// error instead of hanging forever, and the panic is re-raised in this
+// goroutine.
+func (m *CacheMap[K, V]) LoadOrCompute(key K, f func() (V, error)) (value V, loaded bool, err error) {
+	pending := &cacheEntry[V]{done: make(chan struct{})}
+	actual, loaded := m.data.LoadOrStore(key, pending)
+	e := actual.(*cacheEntry[V])
+	if loaded {
+		<-e.done
+		return e.val, true, e.err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			e.err = fmt.Errorf("gsync: CacheMap.LoadOrCompute: f panicked: %v", r)
+			close(e.done)
+			m.data.CompareAndDelete(key, pending)
+			panic(r)
+		}
+	}()
+
+	e.val, e.err = f()
+	close(e.done)
+	if e.err != nil {
+		m.data.CompareAndDelete(key, pending)
+	}
+	return e.val, false, e.err
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if
+// any. If a computation for key is in flight, LoadAndDelete waits for it to
+// finish before deleting the entry, so that a returned value was actually
+// stored and a concurrent LoadOrCompute never sees the key disappear out
+// from under an in-progress computation.
+func (m *CacheMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	actual, ok := m.data.Load(key)
+	if !ok {
+		return value, false
+	}
+	e := actual.(*cacheEntry[V])
+	<-e.done
+	if !m.data.CompareAndDelete(key, e) {
+		return value, false
+	}
+	return e.val, e.err == nil
+}
@@ -0,0 +1,206 @@
+package gsync
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheMapLoadOrComputeSingleFlight(t *testing.T) {
+	cm := CacheMap[string, int]{}
+	var calls int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _, err := cm.LoadOrCompute("key", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				return 42, nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if v != 42 {
+				t.Errorf("got %d, want 42", v)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("f called %d times, want 1", calls)
+	}
+}
+
+func TestCacheMapLoadOrComputeRetriesAfterError(t *testing.T) {
+	cm := CacheMap[string, int]{}
+	wantErr := errors.New("boom")
+
+	_, loaded, err := cm.LoadOrCompute("key", func() (int, error) {
+		return 0, wantErr
+	})
+	if loaded {
+		t.Errorf("loaded = true on first call, want false")
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+
+	v, loaded, err := cm.LoadOrCompute("key", func() (int, error) {
+		return 7, nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if loaded {
+		t.Errorf("loaded = true after prior error, want false")
+	}
+	if v != 7 {
+		t.Errorf("got %d, want 7", v)
+	}
+}
+
+func TestCacheMapLoadAndDeleteWaitsForInFlight(t *testing.T) {
+	cm := CacheMap[string, int]{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		cm.LoadOrCompute("key", func() (int, error) {
+			close(started)
+			<-release
+			return 9, nil
+		})
+	}()
+
+	<-started
+	done := make(chan struct{})
+	go func() {
+		v, loaded := cm.LoadAndDelete("key")
+		if !loaded || v != 9 {
+			t.Errorf("LoadAndDelete = (%d, %v), want (9, true)", v, loaded)
+		}
+		close(done)
+	}()
+
+	close(release)
+	<-done
+}
+
+func TestCacheMapLoadAndDeleteDoesNotDuplicateInFlightCompute(t *testing.T) {
+	cm := CacheMap[string, int]{}
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		cm.LoadOrCompute("key", func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			<-release
+			return 9, nil
+		})
+	}()
+	<-started
+
+	// While the first computation is still in flight, race a LoadAndDelete
+	// against a second LoadOrCompute for the same key. Both should join the
+	// in-flight computation rather than causing f to run a second time.
+	deleteDone := make(chan struct{})
+	go func() {
+		cm.LoadAndDelete("key")
+		close(deleteDone)
+	}()
+	secondDone := make(chan struct{})
+	go func() {
+		cm.LoadOrCompute("key", func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 9, nil
+		})
+		close(secondDone)
+	}()
+
+	// Give both goroutines a chance to observe the still-in-flight entry
+	// before the computation is allowed to finish.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-deleteDone
+	<-secondDone
+
+	if calls != 1 {
+		t.Errorf("f called %d times, want 1", calls)
+	}
+}
+
+func TestCacheMapLoadOrComputePanicDoesNotPoisonKey(t *testing.T) {
+	cm := CacheMap[string, int]{}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatalf("expected LoadOrCompute to re-panic")
+			}
+		}()
+		cm.LoadOrCompute("key", func() (int, error) {
+			panic("boom")
+		})
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		v, loaded, err := cm.LoadOrCompute("key", func() (int, error) {
+			return 42, nil
+		})
+		if loaded || err != nil || v != 42 {
+			t.Errorf("got (%d, %v, %v), want (42, false, nil)", v, loaded, err)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("LoadOrCompute after a panic timed out: key is permanently poisoned")
+	}
+}
+
+func TestCacheMapLoadOrComputePanicUnblocksConcurrentWaiters(t *testing.T) {
+	cm := CacheMap[string, int]{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	waiterErr := make(chan error, 1)
+
+	go func() {
+		defer func() { recover() }()
+		cm.LoadOrCompute("key", func() (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	go func() {
+		_, _, err := cm.LoadOrCompute("key", func() (int, error) {
+			t.Error("waiter should not invoke f itself")
+			return 0, nil
+		})
+		waiterErr <- err
+	}()
+
+	// Give the waiter a chance to join the in-flight entry before the
+	// computation panics.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-waiterErr:
+		if err == nil {
+			t.Errorf("waiter got nil error after f panicked, want non-nil")
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("waiter blocked forever after f panicked")
+	}
+}
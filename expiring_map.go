@@ -0,0 +1,204 @@
+package gsync
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// expiringEntry pairs a value with its expiration time. A zero expires
+// means the entry never expires.
+type expiringEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+func newExpiringEntry[V any](value V, ttl time.Duration) *expiringEntry[V] {
+	e := &expiringEntry[V]{value: value}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	return e
+}
+
+func (e *expiringEntry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+// ExpiringMap is a generic wrapper around Map whose entries carry an
+// optional per-key TTL. Load, Range, Len, Keys and Values transparently
+// skip expired entries, lazily deleting one the moment it's observed; a
+// background janitor goroutine also sweeps the whole map on a fixed
+// interval so expired, never-read keys don't linger in memory.
+//
+// An ExpiringMap must be created with NewExpiringMap; the zero value has no
+// janitor running, so entries are only ever evicted lazily. Call Close when
+// done with the map to stop the janitor; Close is always safe to call, even
+// on a zero-value ExpiringMap.
+type ExpiringMap[K comparable, V any] struct {
+	data   Map[K, *expiringEntry[V]]
+	stop   chan struct{}
+	closed sync.Once
+}
+
+// NewExpiringMap creates an ExpiringMap whose background janitor sweeps
+// expired entries every cleanupInterval. A cleanupInterval <= 0 disables
+// the janitor; entries are still evicted lazily as they're observed.
+func NewExpiringMap[K comparable, V any](cleanupInterval time.Duration) *ExpiringMap[K, V] {
+	m := &ExpiringMap[K, V]{stop: make(chan struct{})}
+	if cleanupInterval > 0 {
+		go m.janitor(cleanupInterval)
+	}
+	return m
+}
+
+// janitor sweeps expired entries every interval until Close is called.
+func (m *ExpiringMap[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.sweep()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// sweep deletes every entry that was expired as of now, each only if it
+// hasn't since been replaced by a concurrent writer.
+func (m *ExpiringMap[K, V]) sweep() {
+	now := time.Now()
+	m.data.Range(func(key K, e *expiringEntry[V]) bool {
+		if e.expired(now) {
+			m.data.CompareAndDelete(key, e)
+		}
+		return true
+	})
+}
+
+// Close stops the background janitor goroutine, if one is running. It is
+// safe to call more than once, and safe to call on a zero-value
+// ExpiringMap, which has no janitor to stop.
+func (m *ExpiringMap[K, V]) Close() {
+	if m.stop == nil {
+		return
+	}
+	m.closed.Do(func() {
+		close(m.stop)
+	})
+}
+
+// StoreWithTTL sets the value for a key, expiring it after ttl. A ttl <= 0
+// means the entry never expires.
+func (m *ExpiringMap[K, V]) StoreWithTTL(key K, value V, ttl time.Duration) {
+	m.data.Store(key, newExpiringEntry(value, ttl))
+}
+
+// LoadOrStoreWithTTL returns the existing, unexpired value for the key if
+// present. Otherwise, it stores the given value with the given ttl and
+// returns it. The loaded result is true if the value was loaded, false if
+// stored.
+func (m *ExpiringMap[K, V]) LoadOrStoreWithTTL(key K, value V, ttl time.Duration) (actual V, loaded bool) {
+	for {
+		candidate := newExpiringEntry(value, ttl)
+		e, loaded := m.data.LoadOrStore(key, candidate)
+		if !loaded {
+			return value, false
+		}
+		if e.expired(time.Now()) {
+			m.data.CompareAndDelete(key, e)
+			continue
+		}
+		return e.value, true
+	}
+}
+
+// Touch extends key's expiration to ttl from now. It reports whether key
+// was present and not already expired.
+func (m *ExpiringMap[K, V]) Touch(key K, ttl time.Duration) bool {
+	for {
+		e, ok := m.data.Load(key)
+		if !ok {
+			return false
+		}
+		if e.expired(time.Now()) {
+			m.data.CompareAndDelete(key, e)
+			return false
+		}
+		if m.data.CompareAndSwap(key, e, newExpiringEntry(e.value, ttl)) {
+			return true
+		}
+		// Lost a race with a concurrent writer; retry.
+	}
+}
+
+// Load returns the value stored in the map for a key, skipping and lazily
+// deleting it if it has expired. The ok result indicates whether an
+// unexpired value was found.
+func (m *ExpiringMap[K, V]) Load(key K) (value V, ok bool) {
+	e, ok := m.data.Load(key)
+	if !ok {
+		return value, false
+	}
+	if e.expired(time.Now()) {
+		m.data.CompareAndDelete(key, e)
+		return value, false
+	}
+	return e.value, true
+}
+
+// Range calls f sequentially for each unexpired key and value present in
+// the map. If f returns false, Range stops the iteration.
+func (m *ExpiringMap[K, V]) Range(f func(key K, value V) bool) {
+	now := time.Now()
+	m.data.Range(func(key K, e *expiringEntry[V]) bool {
+		if e.expired(now) {
+			m.data.CompareAndDelete(key, e)
+			return true
+		}
+		return f(key, e.value)
+	})
+}
+
+// Len returns the number of unexpired items in the map.
+func (m *ExpiringMap[K, V]) Len() int {
+	n := 0
+	m.Range(func(K, V) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Keys returns all unexpired keys in the map.
+func (m *ExpiringMap[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	m.Range(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all unexpired values in the map.
+func (m *ExpiringMap[K, V]) Values() []V {
+	values := make([]V, 0)
+	m.Range(func(_ K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// String returns a string representation of the map's unexpired entries.
+func (m *ExpiringMap[K, V]) String() string {
+	var toPrint []string
+	m.Range(func(key K, value V) bool {
+		toPrint = append(toPrint, fmt.Sprintf("%v: %v", key, value))
+		return true
+	})
+	return fmt.Sprintf("{%v}", strings.Join(toPrint, ", "))
+}
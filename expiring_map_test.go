@@ -0,0 +1,100 @@
+package gsync
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiringMapLoadExpires(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Hour)
+	defer m.Close()
+
+	m.StoreWithTTL("a", 1, 10*time.Millisecond)
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Fatalf("Load before expiry = (%d, %v), want (1, true)", v, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := m.Load("a"); ok {
+		t.Errorf("Load after expiry returned ok = true, want false")
+	}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Len() after expiry = %d, want 0", l)
+	}
+}
+
+func TestExpiringMapNoTTLNeverExpires(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Hour)
+	defer m.Close()
+
+	m.StoreWithTTL("a", 1, 0)
+	time.Sleep(10 * time.Millisecond)
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Errorf("Load = (%d, %v), want (1, true)", v, ok)
+	}
+}
+
+func TestExpiringMapLoadOrStoreWithTTL(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Hour)
+	defer m.Close()
+
+	actual, loaded := m.LoadOrStoreWithTTL("a", 1, time.Hour)
+	if loaded || actual != 1 {
+		t.Fatalf("LoadOrStoreWithTTL = (%d, %v), want (1, false)", actual, loaded)
+	}
+
+	actual, loaded = m.LoadOrStoreWithTTL("a", 2, time.Hour)
+	if !loaded || actual != 1 {
+		t.Errorf("LoadOrStoreWithTTL = (%d, %v), want (1, true)", actual, loaded)
+	}
+}
+
+func TestExpiringMapTouch(t *testing.T) {
+	m := NewExpiringMap[string, int](time.Hour)
+	defer m.Close()
+
+	m.StoreWithTTL("a", 1, 20*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	if !m.Touch("a", time.Hour) {
+		t.Fatalf("Touch returned false for present key")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Errorf("Load after Touch = (%d, %v), want (1, true)", v, ok)
+	}
+
+	if m.Touch("missing", time.Hour) {
+		t.Errorf("Touch returned true for missing key")
+	}
+}
+
+func TestExpiringMapNonPositiveCleanupInterval(t *testing.T) {
+	m := NewExpiringMap[string, int](0)
+	defer m.Close()
+
+	m.StoreWithTTL("a", 1, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := m.Load("a"); ok {
+		t.Errorf("Load after expiry returned ok = true, want false")
+	}
+}
+
+func TestExpiringMapCloseOnZeroValue(t *testing.T) {
+	var m ExpiringMap[string, int]
+	m.StoreWithTTL("a", 1, time.Hour)
+	m.Close()
+	m.Close()
+}
+
+func TestExpiringMapJanitorSweeps(t *testing.T) {
+	m := NewExpiringMap[string, int](5 * time.Millisecond)
+	defer m.Close()
+
+	m.StoreWithTTL("a", 1, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	if l := m.data.Len(); l != 0 {
+		t.Errorf("underlying map still holds %d entries after janitor sweep, want 0", l)
+	}
+}
@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Map is a generic wrapper around sync.Map.
 type Map[K comparable, V any] struct {
 	// data is the underlying sync.Map.
 	data sync.Map
+	// size is the number of items in data, maintained atomically so Len is O(1).
+	size atomic.Int64
+	// sizeMu serializes Clear's delete-all-and-reset against every other
+	// operation that adjusts size, so a Store racing Clear can't increment
+	// size just before Clear's reset wipes it out.
+	sizeMu sync.RWMutex
 }
 
 // Range calls f sequentially for each key and value present in the map.
@@ -47,23 +54,36 @@ func (m *Map[K, V]) Has(key K) bool {
 // LoadAndDelete deletes the value for a key, returning the previous value if any.
 // The loaded result reports whether the key was present.
 func (m *Map[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
 	v, loaded := m.data.LoadAndDelete(key)
 	if !loaded {
 		return value, loaded
 	}
+	m.size.Add(-1)
 	return v.(V), loaded
 }
 
 // Store sets the value for a key.
 func (m *Map[K, V]) Store(key K, value V) {
-	m.data.Store(key, value)
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
+	_, loaded := m.data.Swap(key, value)
+	if !loaded {
+		m.size.Add(1)
+	}
 }
 
 // LoadOrStore returns the existing value for the key if present.
 // Otherwise, it stores and returns the given value.
 // The loaded result is true if the value was loaded, false if stored.
 func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
 	a, loaded := m.data.LoadOrStore(key, value)
+	if !loaded {
+		m.size.Add(1)
+	}
 	return a.(V), loaded
 }
 
@@ -71,19 +91,35 @@ func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
 // Otherwise, it stores and returns the given value.
 // The loaded result is true if the value was loaded, false if stored.
 func (m *Map[K, V]) GetOrStore(key K, value V) (actual V) {
-	a, _ := m.data.LoadOrStore(key, value)
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
+	a, loaded := m.data.LoadOrStore(key, value)
+	if !loaded {
+		m.size.Add(1)
+	}
 	return a.(V)
 }
 
 // Delete deletes the value for a key.
 func (m *Map[K, V]) Delete(key K) {
-	m.data.Delete(key)
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
+	_, loaded := m.data.LoadAndDelete(key)
+	if loaded {
+		m.size.Add(-1)
+	}
 }
 
 // Swap swaps the value for a key and returns the previous value if any.
 // The loaded result reports whether the key was present.
 func (m *Map[K, V]) Swap(key K, new V) (old V, loaded bool) {
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
 	a, loaded := m.data.Swap(key, new)
+	if !loaded {
+		m.size.Add(1)
+		return old, false
+	}
 	return a.(V), loaded
 }
 
@@ -97,7 +133,13 @@ func (m *Map[K, V]) CompareAndSwap(key K, old, new V) bool {
 // CompareAndDelete deletes the entry for key if its value is equal to old.
 // The old value must be of a comparable type.
 func (m *Map[K, V]) CompareAndDelete(key K, value V) bool {
-	return m.data.CompareAndDelete(key, value)
+	m.sizeMu.RLock()
+	defer m.sizeMu.RUnlock()
+	deleted := m.data.CompareAndDelete(key, value)
+	if deleted {
+		m.size.Add(-1)
+	}
+	return deleted
 }
 
 // Compute LoadOrCompute returns the existing value for the key if present.
@@ -115,14 +157,9 @@ func (m *Map[K, V]) ComputeAndLoad(key K, f func(old V) V) (V, bool) {
 	return m.LoadOrStore(key, f(m.Get(key)))
 }
 
-// Len returns the number of items in the map.
+// Len returns the number of items in the map in constant time.
 func (m *Map[K, V]) Len() int {
-	l := 0
-	m.data.Range(func(key, value any) bool {
-		l++
-		return true
-	})
-	return l
+	return int(m.size.Load())
 }
 
 // Keys returns all keys in the map.
@@ -147,10 +184,13 @@ func (m *Map[K, V]) Values() []V {
 
 // Clear removes all items from the map.
 func (m *Map[K, V]) Clear() {
+	m.sizeMu.Lock()
+	defer m.sizeMu.Unlock()
 	m.data.Range(func(key, value any) bool {
 		m.data.Delete(key)
 		return true
 	})
+	m.size.Store(0)
 }
 
 // String returns a string representation of the map.
@@ -18,6 +18,94 @@ func TestMap(t *testing.T) {
 	}
 	wg.Wait()
 	t.Logf(SyncedMap.String())
+	if l := SyncedMap.Len(); l != 100 {
+		t.Errorf("Len() = %d, want 100", l)
+	}
 	SyncedMap.Clear()
 	t.Logf(SyncedMap.String())
+	if l := SyncedMap.Len(); l != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", l)
+	}
+}
+
+func TestMapLen(t *testing.T) {
+	m := Map[string, int]{}
+	if l := m.Len(); l != 0 {
+		t.Errorf("Len() on empty map = %d, want 0", l)
+	}
+
+	m.Store("a", 1)
+	m.Store("b", 2)
+	if l := m.Len(); l != 2 {
+		t.Errorf("Len() = %d, want 2", l)
+	}
+
+	m.Store("a", 3) // overwrite, should not change size
+	if l := m.Len(); l != 2 {
+		t.Errorf("Len() after overwrite = %d, want 2", l)
+	}
+
+	m.Delete("a")
+	if l := m.Len(); l != 1 {
+		t.Errorf("Len() after Delete = %d, want 1", l)
+	}
+
+	m.LoadOrStore("c", 4)
+	m.LoadOrStore("c", 5) // key already present, should not change size or value
+	if l := m.Len(); l != 2 {
+		t.Errorf("Len() after LoadOrStore twice = %d, want 2", l)
+	}
+
+	m.CompareAndDelete("c", 5) // stored value is 4, so this is a no-op
+	if l := m.Len(); l != 2 {
+		t.Errorf("Len() after no-op CompareAndDelete = %d, want 2", l)
+	}
+
+	m.CompareAndDelete("c", 4)
+	if l := m.Len(); l != 1 {
+		t.Errorf("Len() after matching CompareAndDelete = %d, want 1", l)
+	}
+}
+
+// TestMapLenConsistentWithClearUnderConcurrency guards against size
+// under-reporting when Store and Clear race: Clear's delete-all-and-reset
+// must be serialized against concurrent size-adjusting operations, or a
+// Store landing between Clear's Range and its size reset increments a
+// counter Clear is about to wipe out, permanently.
+func TestMapLenConsistentWithClearUnderConcurrency(t *testing.T) {
+	m := Map[int, int]{}
+	stop := make(chan struct{})
+
+	var clearerWg sync.WaitGroup
+	clearerWg.Add(1)
+	go func() {
+		defer clearerWg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				m.Clear()
+			}
+		}
+	}()
+
+	var writersWg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		writersWg.Add(1)
+		go func(g int) {
+			defer writersWg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Store(g*1000+i, i)
+			}
+		}(g)
+	}
+	writersWg.Wait()
+
+	close(stop)
+	clearerWg.Wait()
+
+	if l, keys := m.Len(), len(m.Keys()); l != keys {
+		t.Errorf("Len() = %d, but Keys() has %d entries; want equal", l, keys)
+	}
 }
@@ -0,0 +1,165 @@
+package gsync
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// gobPair mirrors a single Map entry as exported fields, since gob requires
+// exported fields to encode a type.
+type gobPair[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// MarshalJSON implements json.Marshaler. If K is a string-like type or
+// implements encoding.TextMarshaler, the map is encoded as a JSON object
+// keyed by the key's text form. Otherwise, since JSON object keys must be
+// strings, the map is encoded as an array of [key, value] pairs.
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	if isTextKey[K]() {
+		obj := make(map[string]V, m.Len())
+		var err error
+		m.Range(func(key K, value V) bool {
+			var s string
+			if s, err = keyToString(key); err != nil {
+				return false
+			}
+			obj[s] = value
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(obj)
+	}
+
+	pairs := make([][2]any, 0, m.Len())
+	m.Range(func(key K, value V) bool {
+		pairs = append(pairs, [2]any{key, value})
+		return true
+	})
+	return json.Marshal(pairs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts either of the forms
+// produced by MarshalJSON. The map's current contents are replaced
+// atomically: entries are decoded into a temporary map first, and only
+// applied to m, via Clear followed by Store, once decoding succeeds.
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	tmp := &Map[K, V]{}
+	if isTextKey[K]() {
+		var obj map[string]V
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		for s, value := range obj {
+			key, err := stringToKey[K](s)
+			if err != nil {
+				return err
+			}
+			tmp.Store(key, value)
+		}
+	} else {
+		var pairs [][2]json.RawMessage
+		if err := json.Unmarshal(data, &pairs); err != nil {
+			return err
+		}
+		for _, p := range pairs {
+			var key K
+			var value V
+			if err := json.Unmarshal(p[0], &key); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(p[1], &value); err != nil {
+				return err
+			}
+			tmp.Store(key, value)
+		}
+	}
+
+	m.Clear()
+	tmp.Range(func(key K, value V) bool {
+		m.Store(key, value)
+		return true
+	})
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (m *Map[K, V]) GobEncode() ([]byte, error) {
+	pairs := make([]gobPair[K, V], 0, m.Len())
+	m.Range(func(key K, value V) bool {
+		pairs = append(pairs, gobPair[K, V]{Key: key, Value: value})
+		return true
+	})
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pairs); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder. Like UnmarshalJSON, it replaces m's
+// contents atomically: entries are decoded into a temporary map first, and
+// only applied to m, via Clear followed by Store, once decoding succeeds.
+func (m *Map[K, V]) GobDecode(data []byte) error {
+	var pairs []gobPair[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&pairs); err != nil {
+		return err
+	}
+
+	tmp := &Map[K, V]{}
+	for _, p := range pairs {
+		tmp.Store(p.Key, p.Value)
+	}
+
+	m.Clear()
+	tmp.Range(func(key K, value V) bool {
+		m.Store(key, value)
+		return true
+	})
+	return nil
+}
+
+// isTextKey reports whether K should be encoded as a JSON object key:
+// either a string-kinded type, or one implementing encoding.TextMarshaler.
+func isTextKey[K comparable]() bool {
+	var k K
+	if _, ok := any(k).(encoding.TextMarshaler); ok {
+		return true
+	}
+	t := reflect.TypeOf(k)
+	return t != nil && t.Kind() == reflect.String
+}
+
+// keyToString renders key as text for use as a JSON object key.
+func keyToString[K comparable](key K) (string, error) {
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return fmt.Sprintf("%v", key), nil
+}
+
+// stringToKey parses s back into a K produced by keyToString.
+func stringToKey[K comparable](s string) (K, error) {
+	var k K
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(s))
+		return k, err
+	}
+	v := reflect.ValueOf(&k).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(s)
+		return k, nil
+	}
+	return k, fmt.Errorf("gsync: cannot unmarshal key %q into %T", s, k)
+}
@@ -0,0 +1,120 @@
+package gsync
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// userID is a key type implementing encoding.TextMarshaler/TextUnmarshaler,
+// used to exercise the non-string-kinded branch of isTextKey.
+type userID int
+
+func (u userID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("user-%d", int(u))), nil
+}
+
+func (u *userID) UnmarshalText(text []byte) error {
+	var n int
+	if _, err := fmt.Sscanf(string(text), "user-%d", &n); err != nil {
+		return err
+	}
+	*u = userID(n)
+	return nil
+}
+
+func TestMapJSONRoundTripStringKeys(t *testing.T) {
+	m := Map[string, int]{}
+	m.Store("a", 1)
+	m.Store("b", 2)
+
+	data, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got Map[string, int]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.Len() != 2 || got.Get("a") != 1 || got.Get("b") != 2 {
+		t.Errorf("round trip mismatch: %s", got.String())
+	}
+}
+
+func TestMapJSONRoundTripIntKeys(t *testing.T) {
+	m := Map[int, string]{}
+	m.Store(1, "one")
+	m.Store(2, "two")
+
+	data, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var got Map[int, string]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.Len() != 2 || got.Get(1) != "one" || got.Get(2) != "two" {
+		t.Errorf("round trip mismatch: %s", got.String())
+	}
+}
+
+func TestMapJSONRoundTripTextMarshalerKeys(t *testing.T) {
+	m := Map[userID, string]{}
+	m.Store(userID(1), "alice")
+	m.Store(userID(2), "bob")
+
+	data, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"user-1"`)) {
+		t.Errorf("Marshal output %s does not use MarshalText key form", data)
+	}
+
+	var got Map[userID, string]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.Len() != 2 || got.Get(userID(1)) != "alice" || got.Get(userID(2)) != "bob" {
+		t.Errorf("round trip mismatch: %s", got.String())
+	}
+}
+
+func TestMapJSONUnmarshalReplacesContents(t *testing.T) {
+	m := Map[string, int]{}
+	m.Store("stale", 99)
+
+	if err := json.Unmarshal([]byte(`{"a":1}`), &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if m.Has("stale") {
+		t.Errorf("stale entry survived Unmarshal")
+	}
+	if m.Len() != 1 || m.Get("a") != 1 {
+		t.Errorf("unexpected contents: %s", m.String())
+	}
+}
+
+func TestMapGobRoundTrip(t *testing.T) {
+	m := Map[int, string]{}
+	m.Store(1, "one")
+	m.Store(2, "two")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&m); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	var got Map[int, string]
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.Len() != 2 || got.Get(1) != "one" || got.Get(2) != "two" {
+		t.Errorf("round trip mismatch: %s", got.String())
+	}
+}
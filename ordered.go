@@ -0,0 +1,78 @@
+package gsync
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MinKey returns the smallest key stored in m. The ok result is false if m
+// is empty.
+func MinKey[K cmp.Ordered, V any](m *Map[K, V]) (minKey K, ok bool) {
+	m.Range(func(key K, _ V) bool {
+		if !ok || key < minKey {
+			minKey = key
+			ok = true
+		}
+		return true
+	})
+	return minKey, ok
+}
+
+// MaxKey returns the largest key stored in m. The ok result is false if m
+// is empty.
+func MaxKey[K cmp.Ordered, V any](m *Map[K, V]) (maxKey K, ok bool) {
+	m.Range(func(key K, _ V) bool {
+		if !ok || key > maxKey {
+			maxKey = key
+			ok = true
+		}
+		return true
+	})
+	return maxKey, ok
+}
+
+// KeysSorted returns all keys in m in ascending order.
+func KeysSorted[K cmp.Ordered, V any](m *Map[K, V]) []K {
+	keys := m.Keys()
+	slices.Sort(keys)
+	return keys
+}
+
+// RangeSorted calls f sequentially for each key and value in m, visiting
+// keys in ascending order. If f returns false, RangeSorted stops the
+// iteration. Unlike Map.Range, this gives deterministic iteration order at
+// the cost of a sort over m's keys.
+func RangeSorted[K cmp.Ordered, V any](m *Map[K, V], f func(key K, value V) bool) {
+	rangeKeys(m, KeysSorted(m), f)
+}
+
+// RangeSortedFunc is RangeSorted for keys that aren't cmp.Ordered,
+// visiting them in the order defined by less.
+func RangeSortedFunc[K comparable, V any](m *Map[K, V], less func(a, b K) bool, f func(key K, value V) bool) {
+	keys := m.Keys()
+	slices.SortFunc(keys, func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	rangeKeys(m, keys, f)
+}
+
+// rangeKeys calls f for each of keys, in order, skipping any that were
+// deleted out from under the caller between collecting keys and now.
+func rangeKeys[K comparable, V any](m *Map[K, V], keys []K, f func(key K, value V) bool) {
+	for _, key := range keys {
+		value, ok := m.Load(key)
+		if !ok {
+			continue
+		}
+		if !f(key, value) {
+			return
+		}
+	}
+}
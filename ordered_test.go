@@ -0,0 +1,71 @@
+package gsync
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMinMaxKey(t *testing.T) {
+	m := Map[int, string]{}
+	if _, ok := MinKey(&m); ok {
+		t.Errorf("MinKey on empty map: ok = true, want false")
+	}
+	if _, ok := MaxKey(&m); ok {
+		t.Errorf("MaxKey on empty map: ok = true, want false")
+	}
+
+	for _, k := range []int{5, 1, 9, 3} {
+		m.Store(k, "")
+	}
+
+	min, ok := MinKey(&m)
+	if !ok || min != 1 {
+		t.Errorf("MinKey() = (%d, %v), want (1, true)", min, ok)
+	}
+	max, ok := MaxKey(&m)
+	if !ok || max != 9 {
+		t.Errorf("MaxKey() = (%d, %v), want (9, true)", max, ok)
+	}
+}
+
+func TestKeysSortedAndRangeSorted(t *testing.T) {
+	m := Map[int, string]{}
+	for _, k := range []int{5, 1, 9, 3} {
+		m.Store(k, "")
+	}
+
+	got := KeysSorted(&m)
+	want := []int{1, 3, 5, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysSorted() = %v, want %v", got, want)
+	}
+
+	var visited []int
+	RangeSorted(&m, func(key int, _ string) bool {
+		visited = append(visited, key)
+		return true
+	})
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("RangeSorted visited %v, want %v", visited, want)
+	}
+}
+
+func TestRangeSortedFunc(t *testing.T) {
+	m := Map[string, int]{}
+	for _, k := range []string{"bb", "a", "ccc"} {
+		m.Store(k, len(k))
+	}
+
+	var visited []string
+	RangeSortedFunc(&m, func(a, b string) bool {
+		return len(a) < len(b)
+	}, func(key string, _ int) bool {
+		visited = append(visited, key)
+		return true
+	})
+
+	want := []string{"a", "bb", "ccc"}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("RangeSortedFunc visited %v, want %v", visited, want)
+	}
+}
@@ -0,0 +1,281 @@
+package gsync
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// shardCount is the number of shards a ShardedMap splits its keys across.
+// It must be a power of two so shard selection can use a bitmask.
+const shardCount = 32
+
+// SyncMap is the subset of Map and ShardedMap's API that the two share,
+// letting callers pick an implementation and swap between them without
+// touching call sites.
+type SyncMap[K comparable, V any] interface {
+	Load(key K) (value V, ok bool)
+	Store(key K, value V)
+	LoadOrStore(key K, value V) (actual V, loaded bool)
+	LoadAndDelete(key K) (value V, loaded bool)
+	Swap(key K, new V) (old V, loaded bool)
+	CompareAndSwap(key K, old, new V) bool
+	CompareAndDelete(key K, value V) bool
+	Range(f func(key K, value V) bool)
+	Len() int
+	Clear()
+	Keys() []K
+	Values() []V
+	String() string
+}
+
+var _ SyncMap[string, int] = (*Map[string, int])(nil)
+var _ SyncMap[string, int] = (*ShardedMap[string, int])(nil)
+
+// shard is one bucket of a ShardedMap: a plain map guarded by its own
+// RWMutex, so operations on different shards never contend.
+type shard[K comparable, V any] struct {
+	mu   sync.RWMutex
+	data map[K]V
+}
+
+// ShardedMap is a generic map split across shardCount independently locked
+// shards, with the shard for a key chosen by a hash of that key. sync.Map
+// (which Map wraps) is documented as optimized for two narrow access
+// patterns: entries written once and read many times, or goroutines
+// operating on disjoint key sets. ShardedMap targets the case sync.Map
+// doesn't: balanced reads and writes over a shared, overlapping set of
+// keys, where spreading the lock across shards beats a single mutex or
+// sync.Map's internal bookkeeping.
+//
+// A ShardedMap must be created with NewShardedMap; the zero value is not
+// usable.
+type ShardedMap[K comparable, V any] struct {
+	shards [shardCount]shard[K, V]
+	hash   func(K) uint64
+}
+
+// NewShardedMap creates a ShardedMap ready for use. It picks a hasher for K
+// once, up front: a fast path for string and integer keys, and a fallback
+// for everything else.
+func NewShardedMap[K comparable, V any]() *ShardedMap[K, V] {
+	m := &ShardedMap[K, V]{hash: newHasher[K]()}
+	for i := range m.shards {
+		m.shards[i].data = make(map[K]V)
+	}
+	return m
+}
+
+// newHasher returns a func(K) uint64 seeded once for the lifetime of the
+// map it's built for. The fast path is chosen by K's underlying reflect.Kind
+// rather than its exact type, so named types such as `type UserID int64` or
+// `type Name string` take the same fast path as the predeclared types they're
+// defined in terms of. Any other comparable type falls back to hashing its
+// fmt.Sprintf representation.
+func newHasher[K comparable]() func(K) uint64 {
+	seed := maphash.MakeSeed()
+	var zero K
+	var kind reflect.Kind
+	if t := reflect.TypeOf(zero); t != nil {
+		kind = t.Kind()
+	}
+	switch {
+	case kind == reflect.String:
+		return func(k K) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			_, _ = h.WriteString(reflect.ValueOf(k).String())
+			return h.Sum64()
+		}
+	case kind >= reflect.Int && kind <= reflect.Uintptr:
+		return func(k K) uint64 {
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], uint64(reflectInt(k)))
+			var h maphash.Hash
+			h.SetSeed(seed)
+			_, _ = h.Write(buf[:])
+			return h.Sum64()
+		}
+	default:
+		return func(k K) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			_, _ = h.WriteString(fmt.Sprintf("%v", k))
+			return h.Sum64()
+		}
+	}
+}
+
+// reflectInt extracts the bit pattern of any integer kind as an int64.
+func reflectInt(v any) int64 {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr {
+		return int64(rv.Uint())
+	}
+	return rv.Int()
+}
+
+// shardFor returns the shard responsible for key.
+func (m *ShardedMap[K, V]) shardFor(key K) *shard[K, V] {
+	return &m.shards[m.hash(key)&(shardCount-1)]
+}
+
+// Load returns the value stored in the map for a key, or the zero value of V
+// if no value is present. The ok result indicates whether value was found in
+// the map.
+func (m *ShardedMap[K, V]) Load(key K) (value V, ok bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	value, ok = s.data[key]
+	s.mu.RUnlock()
+	return value, ok
+}
+
+// Store sets the value for a key.
+func (m *ShardedMap[K, V]) Store(key K, value V) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	s.data[key] = value
+	s.mu.Unlock()
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m *ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	actual, loaded = s.data[key]
+	if !loaded {
+		s.data[key] = value
+		actual = value
+	}
+	s.mu.Unlock()
+	return actual, loaded
+}
+
+// LoadAndDelete deletes the value for a key, returning the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *ShardedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	value, loaded = s.data[key]
+	if loaded {
+		delete(s.data, key)
+	}
+	s.mu.Unlock()
+	return value, loaded
+}
+
+// Swap swaps the value for a key and returns the previous value if any.
+// The loaded result reports whether the key was present.
+func (m *ShardedMap[K, V]) Swap(key K, new V) (old V, loaded bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	old, loaded = s.data[key]
+	s.data[key] = new
+	s.mu.Unlock()
+	return old, loaded
+}
+
+// CompareAndSwap swaps the old and new values for key
+// if the value stored in the map is equal to old.
+// The old value must be of a comparable type.
+func (m *ShardedMap[K, V]) CompareAndSwap(key K, old, new V) bool {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.data[key]
+	if !ok || any(current) != any(old) {
+		return false
+	}
+	s.data[key] = new
+	return true
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old.
+// The old value must be of a comparable type.
+func (m *ShardedMap[K, V]) CompareAndDelete(key K, value V) bool {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.data[key]
+	if !ok || any(current) != any(value) {
+		return false
+	}
+	delete(s.data, key)
+	return true
+}
+
+// Range calls f sequentially for each key and value present in the map.
+// If f returns false, range stops the iteration. As with sync.Map, Range
+// does not represent a consistent snapshot of the map taken at any single
+// instant: each shard is visited under its own lock, one shard at a time.
+func (m *ShardedMap[K, V]) Range(f func(key K, value V) bool) {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		for k, v := range s.data {
+			if !f(k, v) {
+				s.mu.RUnlock()
+				return
+			}
+		}
+		s.mu.RUnlock()
+	}
+}
+
+// Len returns the number of items in the map.
+func (m *ShardedMap[K, V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.data)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Clear removes all items from the map.
+func (m *ShardedMap[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		s.data = make(map[K]V)
+		s.mu.Unlock()
+	}
+}
+
+// Keys returns all keys in the map.
+func (m *ShardedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in the map.
+func (m *ShardedMap[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	m.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// String returns a string representation of the map.
+func (m *ShardedMap[K, V]) String() string {
+	var toPrint []string
+	m.Range(func(key K, value V) bool {
+		toPrint = append(toPrint, fmt.Sprintf("%v: %v", key, value))
+		return true
+	})
+	return fmt.Sprintf("{%v}", strings.Join(toPrint, ", "))
+}
@@ -0,0 +1,178 @@
+package gsync
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// These benchmarks mirror the shape of the upstream sync/map_test.go
+// benchmark suite, so the numbers here are directly comparable to
+// sync.Map's own BenchmarkLoadMostlyHits, BenchmarkSwapCollision, etc.
+// when deciding between Map and ShardedMap for a given workload.
+
+func BenchmarkShardedMapLoadMostlyHits(b *testing.B) {
+	const hits, misses = 1023, 1
+
+	m := NewShardedMap[int, int]()
+	for i := 0; i < hits; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	var i int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := atomic.AddInt64(&i, 1)
+			if n%(hits+misses) < hits {
+				m.Load(int(n % hits))
+			} else {
+				m.Load(int(n))
+			}
+		}
+	})
+}
+
+func BenchmarkShardedMapLoadMostlyMisses(b *testing.B) {
+	const hits, misses = 1, 1023
+
+	m := NewShardedMap[int, int]()
+	for i := 0; i < hits; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	var i int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := atomic.AddInt64(&i, 1)
+			if n%(hits+misses) < hits {
+				m.Load(int(n % hits))
+			} else {
+				m.Load(int(n))
+			}
+		}
+	})
+}
+
+func BenchmarkShardedMapLoadOrStoreUnique(b *testing.B) {
+	m := NewShardedMap[int, int]()
+
+	b.ResetTimer()
+
+	var i int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := int(atomic.AddInt64(&i, 1))
+			m.LoadOrStore(n, n)
+		}
+	})
+}
+
+func BenchmarkShardedMapLoadOrStoreCollision(b *testing.B) {
+	m := NewShardedMap[int, int]()
+	m.LoadOrStore(0, 0)
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.LoadOrStore(0, 0)
+		}
+	})
+}
+
+func BenchmarkShardedMapSwapCollision(b *testing.B) {
+	m := NewShardedMap[int, int]()
+	m.Store(0, 0)
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.Swap(0, 0)
+		}
+	})
+}
+
+func BenchmarkShardedMapSwapMostlyHits(b *testing.B) {
+	const hits, misses = 1023, 1
+
+	m := NewShardedMap[int, int]()
+	for i := 0; i < hits; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	var i int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := atomic.AddInt64(&i, 1)
+			if n%(hits+misses) < hits {
+				m.Swap(int(n%hits), int(n))
+			} else {
+				m.Swap(int(n), int(n))
+			}
+		}
+	})
+}
+
+func BenchmarkShardedMapCompareAndSwapCollision(b *testing.B) {
+	m := NewShardedMap[int, int]()
+	m.Store(0, 0)
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.CompareAndSwap(0, 0, 0)
+		}
+	})
+}
+
+func BenchmarkShardedMapCompareAndDeleteCollision(b *testing.B) {
+	m := NewShardedMap[int, int]()
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.Store(0, 0)
+			m.CompareAndDelete(0, 0)
+		}
+	})
+}
+
+func BenchmarkShardedMapRange(b *testing.B) {
+	const mapSize = 1 << 10
+
+	m := NewShardedMap[int, int]()
+	for i := 0; i < mapSize; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.Range(func(_ int, _ int) bool { return true })
+		}
+	})
+}
+
+func BenchmarkShardedMapStringKeys(b *testing.B) {
+	m := NewShardedMap[string, int]()
+
+	b.ResetTimer()
+
+	var i int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := atomic.AddInt64(&i, 1)
+			m.Store(fmt.Sprint(n), int(n))
+		}
+	})
+}
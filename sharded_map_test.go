@@ -0,0 +1,123 @@
+package gsync
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestShardedMap(t *testing.T) {
+	m := NewShardedMap[string, int]()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Store(fmt.Sprint(i), i)
+		}(i)
+	}
+	wg.Wait()
+
+	if l := m.Len(); l != 100 {
+		t.Errorf("Len() = %d, want 100", l)
+	}
+	for i := 0; i < 100; i++ {
+		v, ok := m.Load(fmt.Sprint(i))
+		if !ok || v != i {
+			t.Errorf("Load(%d) = (%d, %v), want (%d, true)", i, v, ok, i)
+		}
+	}
+
+	m.Clear()
+	if l := m.Len(); l != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", l)
+	}
+}
+
+func TestShardedMapLoadOrStore(t *testing.T) {
+	m := NewShardedMap[string, int]()
+
+	actual, loaded := m.LoadOrStore("a", 1)
+	if loaded || actual != 1 {
+		t.Errorf("LoadOrStore = (%d, %v), want (1, false)", actual, loaded)
+	}
+
+	actual, loaded = m.LoadOrStore("a", 2)
+	if !loaded || actual != 1 {
+		t.Errorf("LoadOrStore = (%d, %v), want (1, true)", actual, loaded)
+	}
+}
+
+func TestShardedMapSwapAndCompare(t *testing.T) {
+	m := NewShardedMap[string, int]()
+	m.Store("a", 1)
+
+	old, loaded := m.Swap("a", 2)
+	if !loaded || old != 1 {
+		t.Errorf("Swap = (%d, %v), want (1, true)", old, loaded)
+	}
+
+	if m.CompareAndSwap("a", 1, 3) {
+		t.Errorf("CompareAndSwap succeeded against stale old value")
+	}
+	if !m.CompareAndSwap("a", 2, 3) {
+		t.Errorf("CompareAndSwap failed against current value")
+	}
+	if v, _ := m.Load("a"); v != 3 {
+		t.Errorf("got %d, want 3", v)
+	}
+
+	if m.CompareAndDelete("a", 1) {
+		t.Errorf("CompareAndDelete succeeded against stale old value")
+	}
+	if !m.CompareAndDelete("a", 3) {
+		t.Errorf("CompareAndDelete failed against current value")
+	}
+	if m.Len() != 0 {
+		t.Errorf("Len() after CompareAndDelete = %d, want 0", m.Len())
+	}
+}
+
+func TestShardedMapIntKeys(t *testing.T) {
+	m := NewShardedMap[int, string]()
+	for i := 0; i < 50; i++ {
+		m.Store(i, fmt.Sprint(i))
+	}
+	keys := m.Keys()
+	values := m.Values()
+	if len(keys) != 50 || len(values) != 50 {
+		t.Errorf("Keys()/Values() lengths = %d/%d, want 50/50", len(keys), len(values))
+	}
+}
+
+// shardKeyID and name are named types over the predeclared kinds ShardedMap
+// fast-paths on, used to confirm the hasher dispatches on underlying kind
+// rather than exact type.
+type shardKeyID int64
+type name string
+
+func TestShardedMapNamedIntKind(t *testing.T) {
+	m := NewShardedMap[shardKeyID, string]()
+	for i := shardKeyID(0); i < 50; i++ {
+		m.Store(i, fmt.Sprint(i))
+	}
+	if l := m.Len(); l != 50 {
+		t.Errorf("Len() = %d, want 50", l)
+	}
+	if v, ok := m.Load(shardKeyID(7)); !ok || v != "7" {
+		t.Errorf("Load(7) = (%q, %v), want (\"7\", true)", v, ok)
+	}
+}
+
+func TestShardedMapNamedStringKind(t *testing.T) {
+	m := NewShardedMap[name, int]()
+	for i, n := range []name{"alice", "bob", "carol"} {
+		m.Store(n, i)
+	}
+	if l := m.Len(); l != 3 {
+		t.Errorf("Len() = %d, want 3", l)
+	}
+	if v, ok := m.Load(name("bob")); !ok || v != 1 {
+		t.Errorf("Load(\"bob\") = (%d, %v), want (1, true)", v, ok)
+	}
+}